@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// authorizedMethods are the RPCs that PrincipalAuthInterceptor and
+// StreamPrincipalAuthInterceptor enforce the principal allowlist / bearer
+// token against. Both ExecuteCommand and StreamCommand run arbitrary
+// allowlisted commands via exec.CommandContext, so both require the same
+// authorization check.
+var authorizedMethods = map[string]bool{
+	"/" + serviceName + "/ExecuteCommand": true,
+	"/" + serviceName + "/StreamCommand":  true,
+}
+
+// allowedPrincipals returns the configured set of client CNs/SANs permitted
+// to call ExecuteCommand and StreamCommand, from the comma-separated
+// SHELL_SERVICE_ALLOWED_PRINCIPALS env var.
+func allowedPrincipals() map[string]bool {
+	allowed := make(map[string]bool)
+	for _, p := range strings.Split(os.Getenv("SHELL_SERVICE_ALLOWED_PRINCIPALS"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			allowed[p] = true
+		}
+	}
+	return allowed
+}
+
+// PrincipalAuthInterceptor authorizes calls to ExecuteCommand by the CN (or
+// first DNS SAN) of the client's verified TLS certificate. Where no verified
+// certificate is present, it falls back to a shared bearer token carried as
+// "authorization: Bearer <token>" metadata, for environments without PKI.
+func PrincipalAuthInterceptor(allowed map[string]bool, sharedToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !authorizedMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		if err := authorize(ctx, info.FullMethod, allowed, sharedToken); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamPrincipalAuthInterceptor is the streaming counterpart of
+// PrincipalAuthInterceptor, applying the same principal allowlist / bearer
+// token check to StreamCommand (which runs the same exec.CommandContext RCE
+// surface as ExecuteCommand).
+func StreamPrincipalAuthInterceptor(allowed map[string]bool, sharedToken string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !authorizedMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		if err := authorize(ss.Context(), info.FullMethod, allowed, sharedToken); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize enforces the principal allowlist / bearer token check shared by
+// PrincipalAuthInterceptor and StreamPrincipalAuthInterceptor for fullMethod.
+func authorize(ctx context.Context, fullMethod string, allowed map[string]bool, sharedToken string) error {
+	if principal, ok := peerPrincipal(ctx); ok {
+		if allowed[principal] {
+			return nil
+		}
+		return status.Errorf(codes.PermissionDenied, "principal %q is not authorized to call %s", principal, fullMethod)
+	}
+
+	if sharedToken != "" && bearerTokenMatches(ctx, sharedToken) {
+		return nil
+	}
+
+	return status.Errorf(codes.Unauthenticated, "no authorized client certificate or bearer token for %s", fullMethod)
+}
+
+// peerPrincipal extracts the CN, falling back to the first DNS SAN, of the
+// client's verified leaf certificate from the peer's TLS state, if any.
+func peerPrincipal(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+	if leaf.Subject.CommonName != "" {
+		return leaf.Subject.CommonName, true
+	}
+	if len(leaf.DNSNames) > 0 {
+		return leaf.DNSNames[0], true
+	}
+	return "", false
+}
+
+// bearerTokenMatches reports whether the incoming metadata carries
+// "authorization: Bearer <token>" matching token. The comparison is
+// constant-time so a network caller can't use response timing to narrow
+// down the shared secret.
+func bearerTokenMatches(ctx context.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, v := range md.Get("authorization") {
+		got, found := strings.CutPrefix(v, "Bearer ")
+		if !found || len(got) != len(token) {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream implements grpc.ServerStream with a fixed context, enough
+// to exercise a grpc.StreamServerInterceptor in isolation.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context { return f.ctx }
+
+func principalContext(cn string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	p := &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}},
+	}}
+	return peer.NewContext(context.Background(), p)
+}
+
+func bearerContext(token string) context.Context {
+	md := metadata.Pairs("authorization", "Bearer "+token)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func noopUnaryHandler(ctx context.Context, req interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func noopStreamHandler(srv interface{}, stream grpc.ServerStream) error {
+	return nil
+}
+
+func TestPrincipalAuthInterceptorDeniesNoCertNoToken(t *testing.T) {
+	interceptor := PrincipalAuthInterceptor(map[string]bool{"alice": true}, "")
+	for _, method := range []string{"/" + serviceName + "/ExecuteCommand", "/" + serviceName + "/StreamCommand"} {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := interceptor(context.Background(), nil, info, noopUnaryHandler)
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("method %s: code = %v, want Unauthenticated", method, status.Code(err))
+		}
+	}
+}
+
+func TestPrincipalAuthInterceptorDeniesUnlistedPrincipal(t *testing.T) {
+	interceptor := PrincipalAuthInterceptor(map[string]bool{"alice": true}, "")
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + serviceName + "/ExecuteCommand"}
+	_, err := interceptor(principalContext("mallory"), nil, info, noopUnaryHandler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestPrincipalAuthInterceptorAllowsListedPrincipal(t *testing.T) {
+	interceptor := PrincipalAuthInterceptor(map[string]bool{"alice": true}, "")
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + serviceName + "/ExecuteCommand"}
+	resp, err := interceptor(principalContext("alice"), nil, info, noopUnaryHandler)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != "ok" {
+		t.Errorf("resp = %v, want handler's response", resp)
+	}
+}
+
+func TestPrincipalAuthInterceptorAllowsMatchingBearerToken(t *testing.T) {
+	interceptor := PrincipalAuthInterceptor(nil, "s3cret")
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + serviceName + "/ExecuteCommand"}
+	if _, err := interceptor(bearerContext("s3cret"), nil, info, noopUnaryHandler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestPrincipalAuthInterceptorIgnoresUnrelatedMethod(t *testing.T) {
+	interceptor := PrincipalAuthInterceptor(map[string]bool{"alice": true}, "")
+	info := &grpc.UnaryServerInfo{FullMethod: "/" + serviceName + "/Unrelated"}
+	if _, err := interceptor(context.Background(), nil, info, noopUnaryHandler); err != nil {
+		t.Fatalf("unexpected error for unrelated method: %v", err)
+	}
+}
+
+func TestStreamPrincipalAuthInterceptorDeniesNoCertNoToken(t *testing.T) {
+	interceptor := StreamPrincipalAuthInterceptor(map[string]bool{"alice": true}, "")
+	info := &grpc.StreamServerInfo{FullMethod: "/" + serviceName + "/StreamCommand"}
+	stream := &fakeServerStream{ctx: context.Background()}
+	err := interceptor(nil, stream, info, noopStreamHandler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("code = %v, want Unauthenticated", status.Code(err))
+	}
+}
+
+func TestStreamPrincipalAuthInterceptorAllowsListedPrincipal(t *testing.T) {
+	interceptor := StreamPrincipalAuthInterceptor(map[string]bool{"alice": true}, "")
+	info := &grpc.StreamServerInfo{FullMethod: "/" + serviceName + "/StreamCommand"}
+	stream := &fakeServerStream{ctx: principalContext("alice")}
+	if err := interceptor(nil, stream, info, noopStreamHandler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestStreamPrincipalAuthInterceptorAllowsMatchingBearerToken(t *testing.T) {
+	interceptor := StreamPrincipalAuthInterceptor(nil, "s3cret")
+	info := &grpc.StreamServerInfo{FullMethod: "/" + serviceName + "/StreamCommand"}
+	stream := &fakeServerStream{ctx: bearerContext("s3cret")}
+	if err := interceptor(nil, stream, info, noopStreamHandler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// serviceName is the fully-qualified name used to key readiness/health
+// status for the secure shell service, matching the proto package.service.
+const serviceName = "secureshell.SecureShellService"
+
+// shutdownTimeout bounds how long GracefulStop waits for in-flight RPCs to
+// drain before main falls back to Stop().
+var shutdownTimeout = envDuration("SHELL_SERVICE_SHUTDOWN_TIMEOUT", 30*time.Second)
+
+func main() {
+	port := os.Getenv("SHELL_SERVICE_PORT")
+	if port == "" {
+		port = "50051"
+	}
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	shutdownTracing, err := setupTracing(ctx, logger)
+	if err != nil {
+		log.Fatalf("failed to configure tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	serveAdminHTTP(adminAddr(), logger)
+
+	serverOpts, err := serverOptions(logger)
+	if err != nil {
+		log.Fatalf("failed to configure transport security: %v", err)
+	}
+
+	policyRules, err := loadPolicyRules()
+	if err != nil {
+		log.Fatalf("failed to load command policy: %v", err)
+	}
+	policy := NewAllowlistPolicy(policyRules, envDuration("SHELL_SERVICE_DEFAULT_COMMAND_TIMEOUT", 30*time.Second))
+
+	s := NewServer(serverOpts...)
+	ready := s.RegisterService(serviceName, func(gs *grpc.Server) {
+		RegisterSecureShellServiceServer(gs, NewCommandService(ctx, policy))
+	})
+	ready.MarkReady()
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		log.Printf("🚀 Secure Shell Service listening on port %s", port)
+		return s.Serve(lis)
+	})
+	g.Go(func() error {
+		<-gctx.Done()
+		s.SetNotServing(serviceName)
+
+		stopped := make(chan struct{})
+		go func() {
+			s.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(shutdownTimeout):
+			log.Printf("graceful shutdown did not finish within %s, forcing stop", shutdownTimeout)
+			s.Stop()
+		}
+		return nil
+	})
+
+	if err := g.Wait(); err != nil && err != grpc.ErrServerStopped {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
+
+// serverOptions builds the grpc.ServerOption set for transport security and
+// the unary/stream interceptor chains (observability, then authorization).
+// TLS is used whenever it is configured. If it is not, the server refuses
+// to start unless SHELL_SERVICE_ALLOW_INSECURE=1 is set, in which case it
+// listens without transport security.
+func serverOptions(logger *slog.Logger) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+
+	tlsCfg, tlsErr := loadServerTLSConfig()
+	switch {
+	case tlsErr == nil:
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
+	case os.Getenv("SHELL_SERVICE_ALLOW_INSECURE") == "1":
+		log.Printf("⚠️  SHELL_SERVICE_ALLOW_INSECURE=1: serving without TLS, all traffic is unauthenticated and unencrypted")
+	default:
+		return nil, fmt.Errorf("TLS is not configured (%w); set SHELL_SERVICE_ALLOW_INSECURE=1 to explicitly opt out", tlsErr)
+	}
+
+	if os.Getenv("SHELL_SERVICE_TLS_CLIENT_CA_FILE") == "" && os.Getenv("SHELL_SERVICE_AUTH_TOKEN") != "" {
+		log.Printf("⚠️  SHELL_SERVICE_AUTH_TOKEN is set without SHELL_SERVICE_TLS_CLIENT_CA_FILE: " +
+			"authorization is a single shared secret, not per-client/multi-tenant auth")
+	}
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(
+			ObservabilityUnaryInterceptor(logger),
+			PrincipalAuthInterceptor(allowedPrincipals(), os.Getenv("SHELL_SERVICE_AUTH_TOKEN")),
+		),
+		grpc.ChainStreamInterceptor(
+			ObservabilityStreamInterceptor(logger),
+			StreamPrincipalAuthInterceptor(allowedPrincipals(), os.Getenv("SHELL_SERVICE_AUTH_TOKEN")),
+		),
+	)
+
+	return opts, nil
+}
+
+// adminAddr is the listen address for the admin HTTP server (Prometheus
+// /metrics), configurable via SHELL_SERVICE_ADMIN_ADDR.
+func adminAddr() string {
+	if addr := os.Getenv("SHELL_SERVICE_ADMIN_ADDR"); addr != "" {
+		return addr
+	}
+	return ":9090"
+}
+
+// loadPolicyRules loads command allowlist rules from
+// SHELL_SERVICE_POLICY_FILE. With no file configured, the policy is empty
+// and therefore denies every command (deny-by-default).
+func loadPolicyRules() ([]Rule, error) {
+	path := os.Getenv("SHELL_SERVICE_POLICY_FILE")
+	if path == "" {
+		log.Printf("⚠️  SHELL_SERVICE_POLICY_FILE not set: denying all commands")
+		return nil, nil
+	}
+	return LoadPolicyFile(path)
+}
+
+// envDuration reads a time.Duration from the named environment variable,
+// falling back to def if it is unset or not a valid duration.
+func envDuration(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("invalid duration %q for %s, using default %s", v, key, def)
+		return def
+	}
+	return d
+}
+
+// envInt reads an int from the named environment variable, falling back to
+// def if it is unset or not a valid integer.
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("invalid integer %q for %s, using default %d", v, key, def)
+		return def
+	}
+	return n
+}
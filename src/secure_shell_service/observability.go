@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	commandsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "shell_commands_total",
+		Help: "Total ExecuteCommand/StreamCommand RPCs, by method and status code.",
+	}, []string{"method", "code"})
+
+	commandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "shell_command_duration_seconds",
+		Help:    "ExecuteCommand/StreamCommand RPC duration in seconds.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+
+	commandsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "shell_commands_in_flight",
+		Help: "ExecuteCommand/StreamCommand RPCs currently executing.",
+	})
+)
+
+// commandLogTruncateLen bounds how much of a command line is recorded in
+// logs and span attributes.
+const commandLogTruncateLen = 200
+
+func truncateCommand(cmd string) string {
+	if len(cmd) <= commandLogTruncateLen {
+		return cmd
+	}
+	return cmd[:commandLogTruncateLen] + "…"
+}
+
+var nextRequestID atomic.Uint64
+
+// newRequestID returns a process-unique id for correlating a log line, a
+// span, and a metric observation for the same RPC.
+func newRequestID() string {
+	return fmt.Sprintf("req-%d", nextRequestID.Add(1))
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return "unknown"
+}
+
+func commandFromRequest(req interface{}) string {
+	r, ok := req.(*CommandRequest)
+	if !ok {
+		return ""
+	}
+	return truncateCommand(r.Command)
+}
+
+// ObservabilityUnaryInterceptor logs, measures, and traces each unary RPC:
+// a structured log line via slog, shell_commands_total /
+// shell_command_duration_seconds / shell_commands_in_flight Prometheus
+// metrics (for ExecuteCommand/StreamCommand only), and an OTel span.
+func ObservabilityUnaryInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		reqID := newRequestID()
+		cmd := commandFromRequest(req)
+
+		ctx, span := tracer.Start(ctx, info.FullMethod, trace.WithAttributes(
+			attribute.String("rpc.request_id", reqID),
+			attribute.String("shell.command", cmd),
+		))
+		defer span.End()
+
+		isCommand := authorizedMethods[info.FullMethod]
+		if isCommand {
+			commandsInFlight.Inc()
+			defer commandsInFlight.Dec()
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		duration := time.Since(start)
+
+		code := status.Code(err)
+		if isCommand {
+			commandsTotal.WithLabelValues(info.FullMethod, code.String()).Inc()
+			commandDuration.WithLabelValues(info.FullMethod).Observe(duration.Seconds())
+		}
+
+		exitCode := -1
+		if r, ok := resp.(*CommandResponse); ok {
+			if r.Success {
+				exitCode = 0
+			} else {
+				exitCode = 1
+			}
+		}
+		span.SetAttributes(attribute.Int("shell.exit_code", exitCode))
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "rpc completed",
+			slog.String("request_id", reqID),
+			slog.String("peer", peerAddr(ctx)),
+			slog.String("method", info.FullMethod),
+			slog.Duration("duration", duration),
+			slog.Int("exit_code", exitCode),
+			slog.String("command", cmd),
+			slog.String("code", code.String()),
+		)
+
+		return resp, err
+	}
+}
+
+// ObservabilityStreamInterceptor is the streaming-RPC counterpart of
+// ObservabilityUnaryInterceptor, used for StreamCommand.
+func ObservabilityStreamInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		reqID := newRequestID()
+
+		ctx, span := tracer.Start(ss.Context(), info.FullMethod, trace.WithAttributes(
+			attribute.String("rpc.request_id", reqID),
+		))
+		defer span.End()
+
+		isCommand := authorizedMethods[info.FullMethod]
+		if isCommand {
+			commandsInFlight.Inc()
+			defer commandsInFlight.Dec()
+		}
+
+		start := time.Now()
+		err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+		duration := time.Since(start)
+
+		code := status.Code(err)
+		if isCommand {
+			commandsTotal.WithLabelValues(info.FullMethod, code.String()).Inc()
+			commandDuration.WithLabelValues(info.FullMethod).Observe(duration.Seconds())
+		}
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+		}
+
+		logger.LogAttrs(ctx, slog.LevelInfo, "stream rpc completed",
+			slog.String("request_id", reqID),
+			slog.String("peer", peerAddr(ctx)),
+			slog.String("method", info.FullMethod),
+			slog.Duration("duration", duration),
+			slog.String("code", code.String()),
+		)
+
+		return err
+	}
+}
+
+// traceCommandExec starts a child span around the execution of a single
+// exec.Cmd, nested under the RPC span, so a slow command can be correlated
+// to the child process that was run. Only argv[0] is recorded on the span;
+// the arguments are not.
+func traceCommandExec(ctx context.Context, argv []string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "exec.command", trace.WithAttributes(
+		attribute.String("shell.argv0", argv[0]),
+	))
+}
+
+// tracedServerStream overrides Context so handlers observe the span-bearing
+// context built by ObservabilityStreamInterceptor.
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracedServerStream) Context() context.Context { return s.ctx }
+
+// serveAdminHTTP starts the Prometheus /metrics endpoint on addr in the
+// background. A failure to bind is logged rather than fatal: the RPC
+// server can keep running without its admin port.
+func serveAdminHTTP(addr string, logger *slog.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Error("admin listener failed, metrics will not be served", "addr", addr, "error", err)
+		return
+	}
+
+	logger.Info("admin HTTP listening", "addr", addr)
+	go func() {
+		if err := http.Serve(lis, mux); err != nil {
+			logger.Error("admin HTTP server stopped", "error", err)
+		}
+	}()
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/shlex"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy decides whether a requested command line is permitted to run and,
+// if so, resolves it to the literal argv to execute. No shell is ever
+// invoked: argv[0] is run directly via exec.CommandContext.
+type Policy interface {
+	// Resolve parses raw and matches it against the policy's rules. It
+	// returns a PermissionDenied error identifying which rule (or the
+	// absence of one) rejected the command.
+	Resolve(raw string) (argv []string, timeout time.Duration, err error)
+}
+
+// ArgRule validates a single argument position. Exactly one of its fields
+// should be set; an ArgRule with none set accepts any value.
+type ArgRule struct {
+	Regex         string   `yaml:"regex,omitempty" json:"regex,omitempty"`
+	Enum          []string `yaml:"enum,omitempty" json:"enum,omitempty"`
+	PathUnderRoot string   `yaml:"path_under_root,omitempty" json:"path_under_root,omitempty"`
+}
+
+func (a ArgRule) validate(value string) error {
+	switch {
+	case a.Regex != "":
+		re, err := regexp.Compile(a.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", a.Regex, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("value %q does not match pattern %q", value, a.Regex)
+		}
+	case len(a.Enum) > 0:
+		for _, e := range a.Enum {
+			if value == e {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %q is not one of %v", value, a.Enum)
+	case a.PathUnderRoot != "":
+		abs, err := filepath.Abs(value)
+		if err != nil {
+			return fmt.Errorf("resolve path %q: %w", value, err)
+		}
+		root, err := filepath.Abs(a.PathUnderRoot)
+		if err != nil {
+			return fmt.Errorf("resolve root %q: %w", a.PathUnderRoot, err)
+		}
+		rel, err := filepath.Rel(root, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("path %q escapes root %q", value, a.PathUnderRoot)
+		}
+	}
+	return nil
+}
+
+// Rule describes one allowed command template: a literal argv[0] plus a
+// fixed-arity list of validators for the arguments that follow it.
+type Rule struct {
+	Name    string    `yaml:"name" json:"name"`
+	Command string    `yaml:"command" json:"command"`
+	Args    []ArgRule `yaml:"args" json:"args"`
+	Timeout Duration  `yaml:"timeout" json:"timeout"`
+}
+
+// Duration is a time.Duration that unmarshals from the same strings
+// time.ParseDuration accepts (e.g. "5s", "1m30s"), so policy files can write
+// timeouts the way operators actually think about them.
+type Duration time.Duration
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (r Rule) validateArgs(args []string) error {
+	for i, a := range args {
+		if err := r.Args[i].validate(a); err != nil {
+			return fmt.Errorf("argument %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// AllowlistPolicy is a deny-by-default Policy: a command is permitted only
+// if its argv[0] and every subsequent argument match one configured Rule.
+type AllowlistPolicy struct {
+	byCommand      map[string][]Rule
+	defaultTimeout time.Duration
+}
+
+// NewAllowlistPolicy builds an AllowlistPolicy from rules, using
+// defaultTimeout for any rule that doesn't set its own Timeout.
+func NewAllowlistPolicy(rules []Rule, defaultTimeout time.Duration) *AllowlistPolicy {
+	byCommand := make(map[string][]Rule, len(rules))
+	for _, r := range rules {
+		byCommand[r.Command] = append(byCommand[r.Command], r)
+	}
+	return &AllowlistPolicy{byCommand: byCommand, defaultTimeout: defaultTimeout}
+}
+
+// Resolve implements Policy.
+func (p *AllowlistPolicy) Resolve(raw string) ([]string, time.Duration, error) {
+	argv, err := shlex.Split(raw)
+	if err != nil {
+		return nil, 0, status.Errorf(codes.InvalidArgument, "parse command: %v", err)
+	}
+	if len(argv) == 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "command cannot be empty")
+	}
+
+	candidates, ok := p.byCommand[argv[0]]
+	if !ok {
+		return nil, 0, status.Errorf(codes.PermissionDenied, "no policy rule allows command %q", argv[0])
+	}
+
+	var lastErr error
+	for _, rule := range candidates {
+		if len(rule.Args) != len(argv)-1 {
+			lastErr = fmt.Errorf("rule %q expects %d argument(s), got %d", rule.Name, len(rule.Args), len(argv)-1)
+			continue
+		}
+		if err := rule.validateArgs(argv[1:]); err != nil {
+			lastErr = fmt.Errorf("rule %q: %w", rule.Name, err)
+			continue
+		}
+
+		timeout := time.Duration(rule.Timeout)
+		if timeout == 0 {
+			timeout = p.defaultTimeout
+		}
+		return argv, timeout, nil
+	}
+
+	return nil, 0, status.Errorf(codes.PermissionDenied, "command %q denied: %v", raw, lastErr)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// policyFile is the on-disk shape of a policy document: a flat list of
+// rules. Anything not matched by a rule is denied.
+type policyFile struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// LoadPolicyFile reads allowlist rules from a YAML (.yaml/.yml) or JSON
+// (.json) file, selected by extension.
+func LoadPolicyFile(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy file: %w", err)
+	}
+
+	var doc policyFile
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse policy YAML: %w", err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse policy JSON: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported policy file extension %q (want .yaml, .yml, or .json)", ext)
+	}
+
+	return doc.Rules, nil
+}
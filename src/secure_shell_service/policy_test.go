@@ -0,0 +1,80 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAllowlistPolicyResolveAllows(t *testing.T) {
+	policy := NewAllowlistPolicy([]Rule{
+		{
+			Name:    "git-status",
+			Command: "git",
+			Args:    []ArgRule{{Enum: []string{"status"}}},
+		},
+	}, 5*time.Second)
+
+	argv, timeout, err := policy.Resolve("git status")
+	if err != nil {
+		t.Fatalf("Resolve() returned unexpected error: %v", err)
+	}
+	if want := []string{"git", "status"}; !equalArgv(argv, want) {
+		t.Errorf("argv = %v, want %v", argv, want)
+	}
+	if timeout != 5*time.Second {
+		t.Errorf("timeout = %s, want default 5s", timeout)
+	}
+}
+
+func TestAllowlistPolicyResolveDeniesUnknownCommand(t *testing.T) {
+	policy := NewAllowlistPolicy([]Rule{
+		{Name: "ls", Command: "ls", Args: nil},
+	}, time.Second)
+
+	if _, _, err := policy.Resolve("rm -rf /"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Resolve(%q) code = %v, want PermissionDenied", "rm -rf /", status.Code(err))
+	}
+}
+
+func TestAllowlistPolicyResolveDeniesPathEscape(t *testing.T) {
+	policy := NewAllowlistPolicy([]Rule{
+		{
+			Name:    "cat-under-root",
+			Command: "cat",
+			Args:    []ArgRule{{PathUnderRoot: "/srv/data"}},
+		},
+	}, time.Second)
+
+	if _, _, err := policy.Resolve("cat /srv/data/../../etc/passwd"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Resolve() code = %v, want PermissionDenied for path escaping root", status.Code(err))
+	}
+}
+
+func TestAllowlistPolicyResolveDeniesArityMismatch(t *testing.T) {
+	policy := NewAllowlistPolicy([]Rule{
+		{
+			Name:    "git-status",
+			Command: "git",
+			Args:    []ArgRule{{Enum: []string{"status"}}},
+		},
+	}, time.Second)
+
+	if _, _, err := policy.Resolve("git status --short"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Resolve() code = %v, want PermissionDenied for wrong argument count", status.Code(err))
+	}
+}
+
+func equalArgv(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
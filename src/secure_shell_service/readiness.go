@@ -0,0 +1,33 @@
+package main
+
+import "sync/atomic"
+
+// Readiness tracks whether a single registered service is ready to accept
+// traffic. It starts out not ready; callers flip it once with MarkReady.
+type Readiness struct {
+	ready atomic.Bool
+	done  chan struct{}
+}
+
+// NewReadiness returns a Readiness in the not-ready state.
+func NewReadiness() *Readiness {
+	return &Readiness{done: make(chan struct{})}
+}
+
+// MarkReady flips the readiness state to ready. Safe to call more than
+// once; only the first call has an effect.
+func (r *Readiness) MarkReady() {
+	if r.ready.CompareAndSwap(false, true) {
+		close(r.done)
+	}
+}
+
+// IsReady reports whether MarkReady has been called.
+func (r *Readiness) IsReady() bool {
+	return r.ready.Load()
+}
+
+// Done returns a channel that closes once the service becomes ready.
+func (r *Readiness) Done() <-chan struct{} {
+	return r.done
+}
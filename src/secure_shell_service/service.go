@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// commandKillGrace is how long a command is given to exit after SIGTERM
+// (sent on context cancellation) before it is sent SIGKILL.
+var commandKillGrace = envDuration("SHELL_SERVICE_COMMAND_KILL_GRACE", 5*time.Second)
+
+// CommandService implements the gRPC service
+type CommandService struct {
+	UnimplementedSecureShellServiceServer
+
+	// shutdown is canceled when the process is shutting down, so in-flight
+	// commands get torn down alongside the request's own context/timeout.
+	shutdown context.Context
+
+	// policy resolves each request's command line to an allowed argv; no
+	// request is ever handed to a shell.
+	policy Policy
+}
+
+// NewCommandService constructs a CommandService whose in-flight commands are
+// canceled when shutdown is done, in addition to each request's own context,
+// and whose commands are resolved and authorized by policy.
+func NewCommandService(shutdown context.Context, policy Policy) *CommandService {
+	return &CommandService{shutdown: shutdown, policy: policy}
+}
+
+// ExecuteCommand handles secure command execution
+func (s *CommandService) ExecuteCommand(ctx context.Context, req *CommandRequest) (*CommandResponse, error) {
+	// Validate command
+	if req.Command == "" {
+		return nil, status.Error(codes.InvalidArgument, "command cannot be empty")
+	}
+
+	argv, timeout, err := s.policy.Resolve(req.Command)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set timeout
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	shutdown := s.shutdown
+	if shutdown == nil {
+		shutdown = context.Background()
+	}
+	ctx, cancel = mergeContext(ctx, shutdown)
+	defer cancel()
+
+	ctx, span := traceCommandExec(ctx, argv)
+	defer span.End()
+
+	// Execute the policy-resolved argv directly, with no shell
+	// interpretation. Cancel sends SIGTERM first so the process gets a
+	// chance to clean up; WaitDelay escalates to SIGKILL if it hasn't
+	// exited after commandKillGrace.
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = commandKillGrace
+
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		span.RecordError(err)
+		return &CommandResponse{
+			Success: false,
+			Output:  string(output),
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return &CommandResponse{
+		Success: true,
+		Output:  string(output),
+		Error:   "",
+	}, nil
+}
+
+// mergeContext returns a context that is canceled when either a or b is done.
+func mergeContext(a, b context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(a)
+	stop := context.AfterFunc(b, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os/exec"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamChunkSize is the number of buffered bytes at which a CommandChunk is
+// flushed to the client. A chunk can exceed it, since each pipe read is
+// buffered whole before the size is checked.
+var streamChunkSize = envInt("SHELL_SERVICE_STREAM_CHUNK_SIZE", 32*1024)
+
+// streamFlushInterval bounds how long a partially filled chunk is held
+// before being flushed, so low-output commands still stream promptly.
+var streamFlushInterval = envDuration("SHELL_SERVICE_STREAM_FLUSH_INTERVAL", 200*time.Millisecond)
+
+// StreamCommand runs req.Command and streams its stdout/stderr as they
+// arrive instead of buffering the full output like ExecuteCommand. This
+// supports long-running commands (builds, log tails) without hitting the
+// buffered-output timeout, and respects client-side cancellation via
+// stream.Context().Done().
+func (s *CommandService) StreamCommand(req *CommandRequest, stream SecureShellService_StreamCommandServer) error {
+	if req.Command == "" {
+		return status.Error(codes.InvalidArgument, "command cannot be empty")
+	}
+
+	argv, timeout, err := s.policy.Resolve(req.Command)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+	defer cancel()
+
+	shutdown := s.shutdown
+	if shutdown == nil {
+		shutdown = context.Background()
+	}
+	ctx, cancel = mergeContext(ctx, shutdown)
+	defer cancel()
+
+	ctx, span := traceCommandExec(ctx, argv)
+	defer span.End()
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = commandKillGrace
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return status.Errorf(codes.Internal, "stdout pipe: %v", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return status.Errorf(codes.Internal, "stderr pipe: %v", err)
+	}
+
+	var sendMu sync.Mutex
+	send := func(chunk *CommandChunk) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(chunk)
+	}
+
+	var seq uint64
+	nextSeq := func() uint64 {
+		return atomic.AddUint64(&seq, 1)
+	}
+
+	stdoutWriter := newChunkWriter(StreamKind_STDOUT, streamChunkSize, send, nextSeq)
+	stderrWriter := newChunkWriter(StreamKind_STDERR, streamChunkSize, send, nextSeq)
+
+	flushDone := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(streamFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				stdoutWriter.flushPending()
+				stderrWriter.flushPending()
+			case <-flushDone:
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		close(flushDone)
+		return status.Errorf(codes.Internal, "start command: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var pumpErr error
+	var pumpErrMu sync.Mutex
+	setPumpErr := func(err error) {
+		if err == nil {
+			return
+		}
+		pumpErrMu.Lock()
+		defer pumpErrMu.Unlock()
+		if pumpErr == nil {
+			pumpErr = err
+		}
+	}
+
+	pump := func(w *chunkWriter, r io.Reader) {
+		defer wg.Done()
+		if _, err := io.Copy(w, r); err != nil {
+			setPumpErr(err)
+		}
+		setPumpErr(w.flushPending())
+	}
+
+	wg.Add(2)
+	go pump(stdoutWriter, stdout)
+	go pump(stderrWriter, stderr)
+	wg.Wait()
+	close(flushDone)
+
+	waitErr := cmd.Wait()
+
+	pumpErrMu.Lock()
+	firstPumpErr := pumpErr
+	pumpErrMu.Unlock()
+	if firstPumpErr != nil {
+		span.RecordError(firstPumpErr)
+		return status.Errorf(codes.Unavailable, "stream command output: %v", firstPumpErr)
+	}
+
+	exitCode := 0
+	if waitErr != nil {
+		exitErr, ok := waitErr.(*exec.ExitError)
+		if !ok {
+			span.RecordError(waitErr)
+			return status.Errorf(codes.Internal, "wait command: %v", waitErr)
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	return send(&CommandChunk{
+		Done:       true,
+		ExitCode:   int32(exitCode),
+		DurationMs: time.Since(start).Milliseconds(),
+		Seq:        nextSeq(),
+	})
+}
+
+// chunkWriter batches writes from a command's stdout/stderr pipe into
+// CommandChunk messages, flushing once chunkSize bytes have accumulated.
+// A background ticker (see StreamCommand) calls flushPending to bound how
+// long a partial chunk is held for low-output commands.
+type chunkWriter struct {
+	kind      StreamKind
+	chunkSize int
+	send      func(*CommandChunk) error
+	nextSeq   func() uint64
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newChunkWriter(kind StreamKind, chunkSize int, send func(*CommandChunk) error, nextSeq func() uint64) *chunkWriter {
+	return &chunkWriter{kind: kind, chunkSize: chunkSize, send: send, nextSeq: nextSeq}
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.buf = append(w.buf, p...)
+	var flush []byte
+	if len(w.buf) >= w.chunkSize {
+		flush, w.buf = w.buf, nil
+	}
+	w.mu.Unlock()
+
+	if flush != nil {
+		if err := w.send(&CommandChunk{Stream: w.kind, Data: flush, Seq: w.nextSeq()}); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *chunkWriter) flushPending() error {
+	w.mu.Lock()
+	flush := w.buf
+	w.buf = nil
+	w.mu.Unlock()
+
+	if len(flush) == 0 {
+		return nil
+	}
+	return w.send(&CommandChunk{Stream: w.kind, Data: flush, Seq: w.nextSeq()})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// loadServerTLSConfig builds the server's TLS configuration from
+// SHELL_SERVICE_TLS_CERT_FILE / SHELL_SERVICE_TLS_KEY_FILE. If
+// SHELL_SERVICE_TLS_CLIENT_CA_FILE is also set, client certificates are
+// required and verified against that CA bundle (mTLS); otherwise the server
+// authenticates only to the client, and PrincipalAuthInterceptor /
+// StreamPrincipalAuthInterceptor can only authorize callers by the
+// SHELL_SERVICE_AUTH_TOKEN shared bearer token rather than per-client
+// certificate principals — see the warning logged in serverOptions.
+func loadServerTLSConfig() (*tls.Config, error) {
+	certFile := os.Getenv("SHELL_SERVICE_TLS_CERT_FILE")
+	keyFile := os.Getenv("SHELL_SERVICE_TLS_KEY_FILE")
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("SHELL_SERVICE_TLS_CERT_FILE and SHELL_SERVICE_TLS_KEY_FILE must both be set")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load server key pair: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if caFile := os.Getenv("SHELL_SERVICE_TLS_CLIENT_CA_FILE"); caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+var tracer = otel.Tracer("secureshell")
+
+// setupTracing configures the global OTel TracerProvider from
+// SHELL_SERVICE_OTEL_ENDPOINT. With no endpoint configured, tracing is a
+// no-op: tracer.Start still works but spans aren't exported anywhere.
+func setupTracing(ctx context.Context, logger *slog.Logger) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+
+	endpoint := os.Getenv("SHELL_SERVICE_OTEL_ENDPOINT")
+	if endpoint == "" {
+		return noop, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName("secure-shell-service")))
+	if err != nil {
+		return nil, fmt.Errorf("build OTel resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	logger.Info("OpenTelemetry tracing enabled", "endpoint", endpoint)
+	return tp.Shutdown, nil
+}